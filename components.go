@@ -0,0 +1,62 @@
+package simpleflake
+
+import "time"
+
+// BitLayout records the bit allocation used to decompose a SimpleflakeId.
+type BitLayout struct {
+	TimestampBits uint32
+	MachineBits   uint32
+	SequenceBits  uint32
+}
+
+// Components is the structured, typed form of a decomposed SimpleflakeId,
+// as returned by Decompose and (*Generator).Decompose.
+type Components struct {
+	// Time is the wall-clock time the ID was generated at.
+	Time time.Time
+	// Timestamp is the raw timestamp unit stored in the ID, i.e. the
+	// number of milliseconds since the generator's epoch.
+	Timestamp int64
+	// Sequence is the random or monotonic counter value stored in the ID.
+	Sequence uint64
+	// MachineID is the machine/node identifier stored in the ID, or 0 if
+	// the generator reserves no machine bits.
+	MachineID uint64
+	// Bits is the bit layout used to decompose the ID.
+	Bits BitLayout
+}
+
+// Decompose breaks id into its typed components, using the package's
+// default epoch and bit layout (as last set by SetCustomEpoch and
+// SetCustomPrecision). Use (*Generator).Decompose for an ID produced by a
+// custom Generator.
+func Decompose(id SimpleflakeId) Components {
+	return defaultGenerator.Decompose(id)
+}
+
+// Decompose breaks id into its typed components, using g's epoch and bit
+// layout.
+func (g *Generator) Decompose(id SimpleflakeId) Components {
+	seq := extractBits(id, 0, g.sequenceBits)
+	machineID := extractBits(id, g.sequenceBits, g.machineBits)
+	ts := extractBits(id, g.machineBits+g.sequenceBits, g.timestampBits)
+
+	return Components{
+		Time:      time.UnixMilli(int64(ts) + g.epoch),
+		Timestamp: int64(ts),
+		Sequence:  uint64(seq),
+		MachineID: uint64(machineID),
+		Bits: BitLayout{
+			TimestampBits: g.timestampBits,
+			MachineBits:   g.machineBits,
+			SequenceBits:  g.sequenceBits,
+		},
+	}
+}
+
+// Time returns the wall-clock time id was generated at, using the
+// package's default epoch and bit layout. It is a convenience shorthand
+// for Decompose(id).Time.
+func (u SimpleflakeId) Time() time.Time {
+	return Decompose(u).Time
+}
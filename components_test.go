@@ -0,0 +1,63 @@
+package simpleflake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGeneratorDecomposeWithMachineBits(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	g, err := NewGenerator(GeneratorOptions{
+		Epoch:         epoch,
+		TimestampBits: 41,
+		MachineBits:   10,
+		SequenceBits:  13,
+		MachineID:     7,
+		Rand:          zeroReader{},
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	c := g.Decompose(id)
+	if c.MachineID != 7 {
+		t.Fatalf("Decompose(id).MachineID = %d, want 7", c.MachineID)
+	}
+	if c.Sequence != 0 {
+		t.Fatalf("Decompose(id).Sequence = %d, want 0", c.Sequence)
+	}
+	if c.Bits != (BitLayout{TimestampBits: 41, MachineBits: 10, SequenceBits: 13}) {
+		t.Fatalf("Decompose(id).Bits = %+v, want {41 10 13}", c.Bits)
+	}
+
+	wantTime := time.Now().UTC()
+	if diff := c.Time.Sub(wantTime); diff > time.Second || diff < -time.Second {
+		t.Fatalf("Decompose(id).Time = %v, too far from %v", c.Time, wantTime)
+	}
+}
+
+func TestSimpleflakeIdTimeMatchesDecompose(t *testing.T) {
+	id, err := NewSimpleflake()
+	if err != nil {
+		t.Fatalf("NewSimpleflake: %v", err)
+	}
+	if !id.Time().Equal(Decompose(id).Time) {
+		t.Fatalf("id.Time() = %v, want %v", id.Time(), Decompose(id).Time)
+	}
+}
+
+// zeroReader is an io.Reader that always yields zero bytes, for
+// deterministic sequence bits in tests.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
@@ -0,0 +1,146 @@
+package simpleflake
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Encoding selects the string representation produced by MarshalJSON and
+// MarshalText (see SetDefaultEncoding) and by Value (see SetSQLEncoding).
+// SimpleflakeIdToString always renders decimal.
+type Encoding int
+
+const (
+	// EncodingDecimal renders IDs as plain base-10 digits (the historical
+	// behavior, and the default).
+	EncodingDecimal Encoding = iota
+	// EncodingBase32 renders IDs as a fixed-width, sortable base32hex
+	// string, shorter and URL-safe compared to decimal.
+	EncodingBase32
+	// EncodingBase58 renders IDs as a fixed-width, sortable base58 string
+	// (Bitcoin's alphabet), shorter still and free of visually ambiguous
+	// characters.
+	EncodingBase58
+)
+
+// defaultEncoding is the encoding used by MarshalJSON and MarshalText.
+// Change it with SetDefaultEncoding.
+var defaultEncoding = EncodingDecimal
+
+// SetDefaultEncoding changes the string encoding used by MarshalJSON and
+// MarshalText for all SimpleflakeIds. UnmarshalJSON still reads back JSON
+// integers whatever the encoding, but UnmarshalJSON and UnmarshalText decode
+// a string value only with the encoding currently configured, so a decimal
+// string is rejected once a non-decimal encoding is selected.
+func SetDefaultEncoding(e Encoding) {
+	defaultEncoding = e
+}
+
+// base32Encoding is RFC 4648's "Extended Hex" alphabet: it is already in
+// ascending order, so fixed-width encodings of it sort the same as the
+// numbers they represent.
+var base32Encoding = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// base32Width is the number of characters needed to encode a 64-bit value.
+const base32Width = 13
+
+// EncodeBase32 renders id as a 13-character, sortable base32hex string.
+func EncodeBase32(id SimpleflakeId) string {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(id))
+	s := base32Encoding.EncodeToString(buf[:])
+	return s[:base32Width]
+}
+
+// DecodeBase32 parses a string produced by EncodeBase32.
+func DecodeBase32(s string) (SimpleflakeId, error) {
+	if len(s) != base32Width {
+		return 0, errors.New("simpleflake: invalid base32 length")
+	}
+	bs, err := base32Encoding.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+	return SimpleflakeId(binary.BigEndian.Uint64(bs)), nil
+}
+
+// base58Alphabet is Bitcoin's base58 alphabet, in ascending ASCII/value
+// order, so that fixed-width, left-padded encodings sort the same as the
+// numbers they represent.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Width is the number of characters needed to encode the full
+// 64-bit range (58^11 > 2^64-1).
+const base58Width = 11
+
+// EncodeBase58 renders id as an 11-character, sortable base58 string.
+func EncodeBase58(id SimpleflakeId) string {
+	n := uint64(id)
+	var out [base58Width]byte
+	for i := base58Width - 1; i >= 0; i-- {
+		out[i] = base58Alphabet[n%58]
+		n /= 58
+	}
+	return string(out[:])
+}
+
+// DecodeBase58 parses a string produced by EncodeBase58.
+func DecodeBase58(s string) (SimpleflakeId, error) {
+	if len(s) != base58Width {
+		return 0, errors.New("simpleflake: invalid base58 length")
+	}
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		idx := strings.IndexByte(base58Alphabet, s[i])
+		if idx < 0 {
+			return 0, errors.New("simpleflake: invalid base58 character")
+		}
+		n = n*58 + uint64(idx)
+	}
+	return SimpleflakeId(n), nil
+}
+
+// encodeWith renders id using the given encoding.
+func encodeWith(id SimpleflakeId, e Encoding) string {
+	switch e {
+	case EncodingBase32:
+		return EncodeBase32(id)
+	case EncodingBase58:
+		return EncodeBase58(id)
+	default:
+		return strconv.FormatUint(uint64(id), 10)
+	}
+}
+
+// decodeWith parses a string produced by encodeWith(id, e).
+func decodeWith(s string, e Encoding) (SimpleflakeId, error) {
+	switch e {
+	case EncodingBase32:
+		return DecodeBase32(s)
+	case EncodingBase58:
+		return DecodeBase58(s)
+	default:
+		i, err := strconv.ParseUint(s, 10, 64)
+		return SimpleflakeId(i), err
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler using the package's
+// default encoding (see SetDefaultEncoding).
+func (u SimpleflakeId) MarshalText() ([]byte, error) {
+	return []byte(encodeWith(u, defaultEncoding)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing text with the
+// package's current default encoding.
+func (u *SimpleflakeId) UnmarshalText(text []byte) error {
+	id, err := decodeWith(string(text), defaultEncoding)
+	if err != nil {
+		return err
+	}
+	*u = id
+	return nil
+}
@@ -0,0 +1,119 @@
+package simpleflake
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestEncodeDecodeBase32RoundTrip(t *testing.T) {
+	ids := []SimpleflakeId{0, 1, 42, 1 << 40, ^SimpleflakeId(0)}
+	for _, id := range ids {
+		s := EncodeBase32(id)
+		if len(s) != base32Width {
+			t.Fatalf("EncodeBase32(%d) = %q, want length %d", id, s, base32Width)
+		}
+		got, err := DecodeBase32(s)
+		if err != nil {
+			t.Fatalf("DecodeBase32(%q): %v", s, err)
+		}
+		if got != id {
+			t.Fatalf("DecodeBase32(EncodeBase32(%d)) = %d", id, got)
+		}
+	}
+}
+
+func TestEncodeDecodeBase58RoundTrip(t *testing.T) {
+	ids := []SimpleflakeId{0, 1, 42, 1 << 40, ^SimpleflakeId(0)}
+	for _, id := range ids {
+		s := EncodeBase58(id)
+		if len(s) != base58Width {
+			t.Fatalf("EncodeBase58(%d) = %q, want length %d", id, s, base58Width)
+		}
+		got, err := DecodeBase58(s)
+		if err != nil {
+			t.Fatalf("DecodeBase58(%q): %v", s, err)
+		}
+		if got != id {
+			t.Fatalf("DecodeBase58(EncodeBase58(%d)) = %d", id, got)
+		}
+	}
+}
+
+func TestBase32PreservesNumericOrder(t *testing.T) {
+	ids := []SimpleflakeId{5, 1, 1 << 50, 42, 1 << 10, ^SimpleflakeId(0), 0}
+	assertEncodingPreservesOrder(t, ids, EncodeBase32)
+}
+
+func TestBase58PreservesNumericOrder(t *testing.T) {
+	ids := []SimpleflakeId{5, 1, 1 << 50, 42, 1 << 10, ^SimpleflakeId(0), 0}
+	assertEncodingPreservesOrder(t, ids, EncodeBase58)
+}
+
+func assertEncodingPreservesOrder(t *testing.T, ids []SimpleflakeId, encode func(SimpleflakeId) string) {
+	t.Helper()
+
+	sorted := append([]SimpleflakeId(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	encoded := make([]string, len(sorted))
+	for i, id := range sorted {
+		encoded[i] = encode(id)
+	}
+	if !sort.StringsAreSorted(encoded) {
+		t.Fatalf("encoded strings %v are not in numeric order", encoded)
+	}
+}
+
+func TestMarshalTextUnmarshalText(t *testing.T) {
+	defer SetDefaultEncoding(defaultEncoding)
+
+	for _, enc := range []Encoding{EncodingDecimal, EncodingBase32, EncodingBase58} {
+		SetDefaultEncoding(enc)
+
+		id := SimpleflakeId(123456789)
+		text, err := id.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() (encoding %v): %v", enc, err)
+		}
+
+		var got SimpleflakeId
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) (encoding %v): %v", text, enc, err)
+		}
+		if got != id {
+			t.Fatalf("UnmarshalText(MarshalText(%d)) = %d (encoding %v)", id, got, enc)
+		}
+	}
+}
+
+func TestSetDefaultEncodingAffectsMarshalJSON(t *testing.T) {
+	defer SetDefaultEncoding(defaultEncoding)
+
+	id := SimpleflakeId(123456789)
+
+	SetDefaultEncoding(EncodingDecimal)
+	decimalJSON, err := id.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON (decimal): %v", err)
+	}
+	if string(decimalJSON) != `"123456789"` {
+		t.Fatalf("MarshalJSON (decimal) = %s, want \"123456789\"", decimalJSON)
+	}
+
+	SetDefaultEncoding(EncodingBase32)
+	base32JSON, err := id.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON (base32): %v", err)
+	}
+	if string(base32JSON) == string(decimalJSON) {
+		t.Fatalf("MarshalJSON (base32) unexpectedly matches decimal form")
+	}
+
+	var got SimpleflakeId
+	if err := got.UnmarshalJSON(base32JSON); err != nil {
+		t.Fatalf("UnmarshalJSON(%s): %v", base32JSON, err)
+	}
+	if got != id {
+		t.Fatalf("UnmarshalJSON(MarshalJSON(%d)) = %d", id, got)
+	}
+}
@@ -0,0 +1,261 @@
+package simpleflake
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// Errors returned while constructing or operating a Generator.
+var (
+	ErrInvalidBitAllocation = errors.New("simpleflake: timestamp, machine and sequence bits must add up to 64")
+	ErrMachineIDOutOfRange  = errors.New("simpleflake: machine ID does not fit in the configured machine bits")
+	// ErrTimestampOverflow is returned by Next when the current time no
+	// longer fits in the generator's timestamp bits (for the default
+	// 41-bit/year-2000 epoch, around the year 2069), rather than silently
+	// truncating the timestamp and producing a corrupt ID.
+	ErrTimestampOverflow = errors.New("simpleflake: timestamp no longer fits in the configured timestamp bits")
+)
+
+// GeneratorOptions configures a Generator. Any zero-valued field falls back
+// to the package defaults (the same 41-bit timestamp / 23-bit sequence split
+// used by the top-level NewSimpleflake, with no machine bits reserved).
+type GeneratorOptions struct {
+	// Epoch is the zero point for generated timestamps. Defaults to the
+	// package epoch: 2000-01-01 00:00:00 UTC unless changed with
+	// SetCustomEpoch.
+	Epoch time.Time
+
+	// TimestampBits is the number of bits used for the millisecond
+	// timestamp. Defaults to the package precision: 41 unless changed with
+	// SetCustomPrecision.
+	TimestampBits uint32
+
+	// MachineBits is the number of bits reserved to distinguish IDs
+	// generated on different nodes. Defaults to 0 (single-node use).
+	MachineBits uint32
+
+	// SequenceBits is the number of bits used for the per-tick sequence.
+	// Defaults to 64 - TimestampBits - MachineBits.
+	SequenceBits uint32
+
+	// MachineID identifies this generator among others sharing the same
+	// epoch and bit layout. Must fit within MachineBits.
+	MachineID uint64
+
+	// Monotonic makes the generator guarantee strictly increasing IDs,
+	// even when Next is called more than once within the same timestamp
+	// unit. See NewMonotonicGenerator.
+	Monotonic bool
+
+	// Clock supplies the current time. Defaults to time.Now; tests can
+	// substitute it to exercise ordering and timestamp-overflow behavior
+	// deterministically.
+	Clock func() time.Time
+
+	// Rand supplies randomness for the sequence bits. Defaults to
+	// crypto/rand.Reader.
+	Rand io.Reader
+}
+
+// Generator builds SimpleflakeIds from an independently configured epoch,
+// bit layout and machine ID, so that multiple nodes can generate IDs
+// concurrently without colliding. The zero value is not usable; construct
+// one with NewGenerator.
+type Generator struct {
+	epoch         int64
+	timestampBits uint32
+	machineBits   uint32
+	sequenceBits  uint32
+	machineID     uint64
+	maxSequence   SimpleflakeId
+
+	clock func() time.Time
+	rand  io.Reader
+
+	monotonic bool
+	mu        sync.Mutex
+	lastTick  int64
+	lastSeq   SimpleflakeId
+}
+
+// NewGenerator builds a Generator from opts, filling in defaults for any
+// zero-valued fields.
+func NewGenerator(opts GeneratorOptions) (*Generator, error) {
+	if opts.TimestampBits == 0 {
+		opts.TimestampBits = timestampBits
+	}
+	if opts.TimestampBits+opts.MachineBits > 64 {
+		return nil, ErrInvalidBitAllocation
+	}
+	if opts.SequenceBits == 0 {
+		opts.SequenceBits = 64 - opts.TimestampBits - opts.MachineBits
+	}
+	if opts.TimestampBits+opts.MachineBits+opts.SequenceBits != 64 {
+		return nil, ErrInvalidBitAllocation
+	}
+	if opts.MachineID >= uint64(1)<<opts.MachineBits {
+		return nil, ErrMachineIDOutOfRange
+	}
+
+	customEpoch := epoch
+	if !opts.Epoch.IsZero() {
+		customEpoch = opts.Epoch.UTC().UnixNano() / nano
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+	randSource := opts.Rand
+	if randSource == nil {
+		randSource = rand.Reader
+	}
+
+	return &Generator{
+		epoch:         customEpoch,
+		timestampBits: opts.TimestampBits,
+		machineBits:   opts.MachineBits,
+		sequenceBits:  opts.SequenceBits,
+		machineID:     opts.MachineID,
+		maxSequence:   SimpleflakeId(1)<<opts.SequenceBits - 1,
+		monotonic:     opts.Monotonic,
+		clock:         clock,
+		rand:          randSource,
+	}, nil
+}
+
+// NewMonotonicGenerator builds a Generator that guarantees strictly
+// increasing IDs across successive calls to Next, even within the same
+// timestamp unit. It is equivalent to NewGenerator with Monotonic: true.
+func NewMonotonicGenerator(opts GeneratorOptions) (*Generator, error) {
+	opts.Monotonic = true
+	return NewGenerator(opts)
+}
+
+// Next generates a new roughly-ordered, unique ID from the generator's
+// current timestamp, machine ID and a random sequence. If the generator was
+// built with Monotonic: true, successive IDs are strictly increasing.
+func (g *Generator) Next() (SimpleflakeId, error) {
+	if g.monotonic {
+		return g.nextMonotonic()
+	}
+	seq, err := g.randomSequence()
+	if err != nil {
+		return 0, err
+	}
+	ts, err := g.timestamp()
+	if err != nil {
+		return 0, err
+	}
+	return g.buildId(ts, seq), nil
+}
+
+// timestamp returns the current time as a generator-relative timestamp
+// unit, or ErrTimestampOverflow if it no longer fits in timestampBits.
+func (g *Generator) timestamp() (int64, error) {
+	ts := g.clock().UnixNano()/nano - g.epoch
+	if ts < 0 || ts >= int64(1)<<g.timestampBits {
+		return 0, ErrTimestampOverflow
+	}
+	return ts, nil
+}
+
+// monotonicWaitBudget bounds how long nextMonotonic will wait, in real wall
+// clock time, for the clock to advance past the current tick once the
+// sequence bits are exhausted. It is independent of a Generator's injected
+// Clock, which may be frozen on purpose (e.g. in tests).
+const monotonicWaitBudget = 200 * time.Millisecond
+
+// ErrSequenceExhausted is returned by a monotonic Generator's Next when the
+// sequence bits fill up within a single timestamp unit and the clock does
+// not advance past that tick within monotonicWaitBudget.
+var ErrSequenceExhausted = errors.New("simpleflake: sequence exhausted and clock did not advance")
+
+// nextMonotonic produces the next ID for a monotonic generator. Within a
+// single timestamp unit it increments an internal counter seeded randomly
+// at the start of the tick, so that IDs generated back-to-back never
+// collide or go backwards. If the counter exhausts the sequence bits
+// before the clock advances, it waits for the next tick (the same
+// strategy used by Terraform's ordered UniqueId and ULID's monotonic
+// mode), giving up with ErrSequenceExhausted after monotonicWaitBudget
+// rather than blocking forever.
+func (g *Generator) nextMonotonic() (SimpleflakeId, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ts, err := g.timestamp()
+	if err != nil {
+		return 0, err
+	}
+	if ts < g.lastTick {
+		ts = g.lastTick
+	}
+
+	if ts == g.lastTick {
+		g.lastSeq++
+		if g.lastSeq > g.maxSequence {
+			deadline := time.Now().Add(monotonicWaitBudget)
+			for ts <= g.lastTick {
+				if time.Now().After(deadline) {
+					return 0, ErrSequenceExhausted
+				}
+				time.Sleep(time.Millisecond)
+				ts, err = g.timestamp()
+				if err != nil {
+					return 0, err
+				}
+			}
+			seeded, err := g.randomSequence()
+			if err != nil {
+				return 0, err
+			}
+			g.lastSeq = seeded
+		}
+	} else {
+		seeded, err := g.randomSequence()
+		if err != nil {
+			return 0, err
+		}
+		g.lastSeq = seeded
+	}
+
+	g.lastTick = ts
+	return g.buildId(ts, g.lastSeq), nil
+}
+
+// buildId assembles a SimpleflakeId from the timestamp, machine ID and
+// sequence according to the generator's bit layout.
+func (g *Generator) buildId(ts int64, seq SimpleflakeId) SimpleflakeId {
+	id := SimpleflakeId(ts) << (g.machineBits + g.sequenceBits)
+	id |= SimpleflakeId(g.machineID) << g.sequenceBits
+	id |= seq
+	return id
+}
+
+// randomSequence draws a random sequence value that fits in the
+// generator's sequence bits.
+func (g *Generator) randomSequence() (SimpleflakeId, error) {
+	max := big.NewInt(int64((math.Pow(2, float64(g.sequenceBits))) - 1))
+	random, err := rand.Int(g.rand, max)
+	if err != nil {
+		return 0, err
+	}
+	return SimpleflakeId(random.Uint64()), nil
+}
+
+// defaultGenerator backs the package-level NewSimpleflake and friends, kept
+// in sync with the legacy epoch/timestampBits globals by SetCustomEpoch and
+// SetCustomPrecision.
+var defaultGenerator = &Generator{
+	epoch:         epoch,
+	timestampBits: timestampBits,
+	sequenceBits:  randomBits,
+	maxSequence:   SimpleflakeId(1)<<randomBits - 1,
+	clock:         time.Now,
+	rand:          rand.Reader,
+}
@@ -1,11 +1,8 @@
 package simpleflake
 
 import (
-	"crypto/rand"
 	"encoding/json"
 	"errors"
-	"math"
-	"math/big"
 	"strconv"
 	"time"
 )
@@ -23,14 +20,13 @@ var (
 	randomBits    uint32 = 64 - timestampBits
 )
 
-// Generate a new 64-bit, roughly-ordered, unique ID
+// Generate a new 64-bit, roughly-ordered, unique ID.
+//
+// This is a thin wrapper around a package-level default Generator; use
+// NewGenerator directly to deploy across multiple nodes or to customize the
+// bit layout.
 func NewSimpleflake() (id SimpleflakeId, err error) {
-	seq, err := randomSequence()
-	if err != nil {
-		return
-	}
-	id = buildId(customTimestamp(time.Now()), seq)
-	return
+	return defaultGenerator.Next()
 }
 
 // Parse a previously generated ID
@@ -44,6 +40,7 @@ func Parse(id SimpleflakeId) [2]SimpleflakeId {
 // Set the epoch to a custom time
 func SetCustomEpoch(t time.Time) {
 	epoch = t.UTC().UnixNano() / nano
+	defaultGenerator.epoch = epoch
 }
 
 // Set the precision level of the timestamp
@@ -51,16 +48,9 @@ func SetCustomPrecision(bits uint32) {
 	timestampBits = bits
 	// reset random bit length
 	randomBits = 64 - timestampBits
-}
-
-// Build a new 64-bit ID from the timestamp and random sequence
-func buildId(ts int64, seq SimpleflakeId) SimpleflakeId {
-	return (SimpleflakeId(ts) << randomBits) | seq
-}
-
-// Get a custom timestamp to be used to generate a new ID
-func customTimestamp(t time.Time) int64 {
-	return t.UnixNano()/nano - epoch
+	defaultGenerator.timestampBits = timestampBits
+	defaultGenerator.sequenceBits = randomBits
+	defaultGenerator.maxSequence = SimpleflakeId(1)<<randomBits - 1
 }
 
 // Extract bits from a simpleflakeId
@@ -69,20 +59,10 @@ func extractBits(data SimpleflakeId, shift uint32, length uint32) SimpleflakeId
 	return ((data & bitmask) >> shift)
 }
 
-// Get a random sequence to be used to generate a new ID
-func randomSequence() (seq SimpleflakeId, err error) {
-	// the maximum random sequence we can generate is 2^randomBits-1
-	max := big.NewInt(int64((math.Pow(2, float64(randomBits))) - 1))
-	random, err := rand.Int(rand.Reader, max)
-	if err == nil {
-		seq = SimpleflakeId(random.Uint64())
-	}
-	return
-}
-
+// MarshalJSON renders the ID as a JSON string, using the package's default
+// encoding (decimal unless changed with SetDefaultEncoding).
 func (u SimpleflakeId) MarshalJSON() ([]byte, error) {
-	n := uint64(u)
-	s := strconv.FormatUint(n, 10)
+	s := encodeWith(u, defaultEncoding)
 
 	j, e := json.Marshal(s)
 
@@ -104,10 +84,11 @@ func (u *SimpleflakeId) UnmarshalJSON(bs []byte) error {
 	if err := json.Unmarshal(bs, &s); err != nil {
 		return errors.New("expected a string or an integer")
 	}
-	if err := json.Unmarshal([]byte(s), &i); err != nil {
+	id, err := decodeWith(s, defaultEncoding)
+	if err != nil {
 		return err
 	}
-	*u = SimpleflakeId(i)
+	*u = id
 	return nil
 }
 
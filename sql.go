@@ -0,0 +1,66 @@
+package simpleflake
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// sqlEncoding is the encoding used by Value and Scan. It is independent of
+// defaultEncoding (used by MarshalJSON/MarshalText) so that changing the
+// JSON wire format with SetDefaultEncoding can't silently change the type
+// Value hands to a BIGINT column. Change it with SetSQLEncoding.
+var sqlEncoding = EncodingDecimal
+
+// SetSQLEncoding changes the encoding used by Value and Scan for all
+// SimpleflakeIds. Leave it at EncodingDecimal (the default) to store IDs as
+// an int64 in a BIGINT column; select EncodingBase32 or EncodingBase58 to
+// store them as that encoding's string form instead.
+func SetSQLEncoding(e Encoding) {
+	sqlEncoding = e
+}
+
+// Value implements driver.Valuer, so a SimpleflakeId can be passed directly
+// as a query argument to database/sql. It is stored as an int64 to fit a
+// BIGINT column, unless SetSQLEncoding has selected a non-decimal encoding,
+// in which case it is stored as that encoding's string form.
+func (u SimpleflakeId) Value() (driver.Value, error) {
+	if sqlEncoding == EncodingDecimal {
+		return int64(u), nil
+	}
+	return encodeWith(u, sqlEncoding), nil
+}
+
+// Scan implements sql.Scanner, so a SimpleflakeId can be read directly out
+// of a database/sql row (e.g. a `RETURNING id` clause). It accepts int64,
+// []byte and string, decoding the latter two with the encoding currently
+// configured via SetSQLEncoding and falling back to decimal.
+func (u *SimpleflakeId) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		*u = SimpleflakeId(v)
+		return nil
+	case []byte:
+		return u.scanString(string(v))
+	case string:
+		return u.scanString(v)
+	case nil:
+		return fmt.Errorf("simpleflake: cannot scan NULL into SimpleflakeId")
+	default:
+		return fmt.Errorf("simpleflake: unsupported Scan type %T", src)
+	}
+}
+
+func (u *SimpleflakeId) scanString(s string) error {
+	if sqlEncoding != EncodingDecimal {
+		if id, err := decodeWith(s, sqlEncoding); err == nil {
+			*u = id
+			return nil
+		}
+	}
+	id, err := decodeWith(s, EncodingDecimal)
+	if err != nil {
+		return fmt.Errorf("simpleflake: cannot scan %q into SimpleflakeId: %w", s, err)
+	}
+	*u = id
+	return nil
+}
@@ -0,0 +1,130 @@
+package simpleflake
+
+import "testing"
+
+func TestSimpleflakeIdScanInt64(t *testing.T) {
+	var id SimpleflakeId
+	if err := id.Scan(int64(123456789)); err != nil {
+		t.Fatalf("Scan(int64): %v", err)
+	}
+	if id != 123456789 {
+		t.Fatalf("Scan(int64) = %d, want 123456789", id)
+	}
+}
+
+func TestSimpleflakeIdScanBytesAndString(t *testing.T) {
+	var fromBytes, fromString SimpleflakeId
+	if err := fromBytes.Scan([]byte("123456789")); err != nil {
+		t.Fatalf("Scan([]byte): %v", err)
+	}
+	if fromBytes != 123456789 {
+		t.Fatalf("Scan([]byte) = %d, want 123456789", fromBytes)
+	}
+
+	if err := fromString.Scan("123456789"); err != nil {
+		t.Fatalf("Scan(string): %v", err)
+	}
+	if fromString != 123456789 {
+		t.Fatalf("Scan(string) = %d, want 123456789", fromString)
+	}
+}
+
+func TestSimpleflakeIdScanNil(t *testing.T) {
+	var id SimpleflakeId
+	if err := id.Scan(nil); err == nil {
+		t.Fatal("Scan(nil) expected an error, got nil")
+	}
+}
+
+func TestSimpleflakeIdScanUnsupportedType(t *testing.T) {
+	var id SimpleflakeId
+	if err := id.Scan(3.14); err == nil {
+		t.Fatal("Scan(float64) expected an error, got nil")
+	}
+}
+
+func TestSimpleflakeIdValueDecimal(t *testing.T) {
+	defer SetSQLEncoding(sqlEncoding)
+	SetSQLEncoding(EncodingDecimal)
+
+	id := SimpleflakeId(123456789)
+	v, err := id.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if v != int64(123456789) {
+		t.Fatalf("Value() = %v (%T), want int64(123456789)", v, v)
+	}
+}
+
+func TestSimpleflakeIdValueEncodedString(t *testing.T) {
+	defer SetSQLEncoding(sqlEncoding)
+	SetSQLEncoding(EncodingBase58)
+
+	id := SimpleflakeId(123456789)
+	v, err := id.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	s, ok := v.(string)
+	if !ok {
+		t.Fatalf("Value() = %v (%T), want a string", v, v)
+	}
+
+	var scanned SimpleflakeId
+	if err := scanned.Scan(s); err != nil {
+		t.Fatalf("Scan(%q): %v", s, err)
+	}
+	if scanned != id {
+		t.Fatalf("Scan(Value()) = %d, want %d", scanned, id)
+	}
+}
+
+// TestSimpleflakeIdScanDisambiguatesSmallValues guards against a
+// regression where Scan tried decimal before the configured SQL encoding:
+// small/sparse IDs can encode to strings that also happen to be valid
+// (but wrong) decimal numbers, e.g. SimpleflakeId(1) in base58 is
+// "11111111112" and in base32 is "0000000000002".
+func TestSimpleflakeIdScanDisambiguatesSmallValues(t *testing.T) {
+	defer SetSQLEncoding(sqlEncoding)
+
+	for _, enc := range []Encoding{EncodingBase32, EncodingBase58} {
+		SetSQLEncoding(enc)
+
+		id := SimpleflakeId(1)
+		v, err := id.Value()
+		if err != nil {
+			t.Fatalf("Value() (encoding %v): %v", enc, err)
+		}
+		s := v.(string)
+
+		var scanned SimpleflakeId
+		if err := scanned.Scan(s); err != nil {
+			t.Fatalf("Scan(%q) (encoding %v): %v", s, enc, err)
+		}
+		if scanned != id {
+			t.Fatalf("Scan(Value()) (encoding %v) = %d, want %d", enc, scanned, id)
+		}
+	}
+}
+
+// TestSetSQLEncodingIndependentOfDefaultEncoding guards against a
+// regression where Value/Scan shared defaultEncoding with MarshalJSON, so
+// selecting a shorter JSON encoding would silently switch Value's output
+// type away from int64 and break a BIGINT column.
+func TestSetSQLEncodingIndependentOfDefaultEncoding(t *testing.T) {
+	defer SetDefaultEncoding(defaultEncoding)
+	defer SetSQLEncoding(sqlEncoding)
+
+	SetSQLEncoding(EncodingDecimal)
+	SetDefaultEncoding(EncodingBase58)
+
+	id := SimpleflakeId(123456789)
+	v, err := id.Value()
+	if err != nil {
+		t.Fatalf("Value(): %v", err)
+	}
+	if _, ok := v.(int64); !ok {
+		t.Fatalf("Value() = %v (%T), want int64 (SetDefaultEncoding must not affect SQL encoding)", v, v)
+	}
+}